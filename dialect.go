@@ -0,0 +1,246 @@
+package naivequeryfilters
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/kirill-scherba/omap"
+)
+
+/*
+Dialect selects how Build renders SQL placeholders and LIKE string concatenation for a
+target database, since not every database speaks MySQL's `?` positional placeholders
+and CONCAT(...) syntax.
+*/
+type Dialect int
+
+const (
+	// DialectMySQL renders `?` placeholders and CONCAT('%', ?, '%')-style LIKE values.
+	// This is the SQL shape filterParam.Sql() already produces, so it's a no-op dialect.
+	DialectMySQL Dialect = iota
+
+	// DialectPostgres renders `$1, $2, ...` placeholders and '%' || ? || '%'-style
+	// LIKE concatenation.
+	DialectPostgres
+
+	// DialectSQLite renders `?` placeholders (same as MySQL) and '%' || ? || '%'-style
+	// LIKE concatenation, since SQLite has no built-in CONCAT function.
+	DialectSQLite
+
+	// DialectSQLServer renders `@p1, @p2, ...` placeholders.
+	DialectSQLServer
+
+	// DialectNamed renders generic `:arg1, :arg2, ...` placeholders. This is a distinct,
+	// simpler convention from BuildNamed's `:col1_0`-style names: Rebind only ever sees
+	// already-rendered SQL text with no column context to draw a name from, so it can't
+	// reproduce BuildNamed's per-column naming. Callers who want `:col1_0`-style
+	// placeholders tied to column names must call BuildNamed directly instead of
+	// Build+Rebind(..., DialectNamed).
+	DialectNamed
+)
+
+/*
+Rebind rewrites MySQL-style sql (as produced by Build/filterParam.Sql(), using `?`
+placeholders and CONCAT('%', ?, '%')-style LIKE values) into the placeholder and LIKE
+concatenation syntax of dialect. Build itself always emits the MySQL-style shape
+regardless of target database; get dialect-correct SQL by calling Rebind(filter.SqlFilters,
+dialect) on Build's result (or BuildWithDialect, which does that in one call), not by
+expecting Build to take a Dialect itself.
+*/
+func Rebind(sql string, dialect Dialect) string {
+	sql = rebindApprox(sql, dialect)
+	sql = rebindConcat(sql, dialect)
+	switch dialect {
+	case DialectPostgres:
+		return rebindPlaceholders(sql, func(i int) string { return fmt.Sprintf("$%d", i) })
+	case DialectSQLServer:
+		return rebindPlaceholders(sql, func(i int) string { return fmt.Sprintf("@p%d", i) })
+	case DialectNamed:
+		return rebindPlaceholders(sql, func(i int) string { return fmt.Sprintf(":arg%d", i) })
+	default: // DialectMySQL, DialectSQLite: `?` placeholders stay as-is.
+		return sql
+	}
+}
+
+/*
+rebindConcat rewrites the CONCAT(...) shapes filterParam.Sql() emits for LIKE/startswith/
+endswith into '... || ? || ...' on dialects without a CONCAT function (Postgres, SQLite).
+*/
+func rebindConcat(sql string, dialect Dialect) string {
+	if dialect != DialectPostgres && dialect != DialectSQLite {
+		return sql
+	}
+	replacer := strings.NewReplacer(
+		`CONCAT('%', ?, '%')`, `'%' || ? || '%'`,
+		`CONCAT(?, '%')`, `? || '%'`,
+		`CONCAT('%', ?)`, `'%' || ?`,
+	)
+	return replacer.Replace(sql)
+}
+
+/*
+approxFallbackPattern matches the portable __approx/__ilike fallback shape
+filterParam.Sql() emits for QUERY_TOKEN_APPROX, capturing the column name so
+rebindApprox/Filter.WithTrigram can rewrite it without knowing the column up front.
+*/
+var approxFallbackPattern = regexp.MustCompile(`LOWER\(([^()]+)\) LIKE CONCAT\('%', LOWER\(\?\), '%'\)`)
+
+/*
+rebindApprox rewrites the portable __approx/__ilike fallback shape into `col ILIKE
+'%' || ? || '%'` on DialectPostgres, where ILIKE is natively case-insensitive. Other
+dialects keep the portable fallback, since they have no ILIKE equivalent.
+*/
+func rebindApprox(sql string, dialect Dialect) string {
+	if dialect != DialectPostgres {
+		return sql
+	}
+	return approxFallbackPattern.ReplaceAllString(sql, `$1 ILIKE '%' || ? || '%'`)
+}
+
+/*
+WithTrigram rewrites every __approx/__ilike filter in f.SqlFilters from its portable LIKE
+fallback shape into a pg_trgm similarity predicate, `col % ?`, and returns the result
+alongside a "SET pg_trgm.similarity_threshold = ?" statement appended to
+f.Preamble/f.PreambleValues. Run that statement before f.SqlFilters on a
+pg_trgm-enabled Postgres connection.
+
+Call WithTrigram on a Filter straight out of Build/BuildFromFilterExpression, before
+Rebind: Rebind renumbers `?` into the target dialect's placeholder syntax, and
+WithTrigram's rewrite only recognizes the fallback shape's `?` placeholders.
+
+pg_trgm's `%` operator only uses an index when one exists, so approx-filtered columns
+need a GIN trigram index, e.g.:
+
+	CREATE INDEX ON table_name USING gin (col gin_trgm_ops);
+
+See https://www.postgresql.org/docs/current/pgtrgm.html.
+*/
+func (f Filter) WithTrigram(threshold float64) Filter {
+	f.SqlFilters = approxFallbackPattern.ReplaceAllString(f.SqlFilters, `$1 % ?`)
+	f.Preamble = append(append([]string{}, f.Preamble...), "SET pg_trgm.similarity_threshold = ?")
+	f.PreambleValues = append(append([][]any{}, f.PreambleValues...), []any{threshold})
+	return f
+}
+
+/*
+rebindPlaceholders replaces every `?` placeholder in sql (outside of single-quoted string
+literals) with name(i), where i is the placeholder's 1-indexed position in sql.
+*/
+func rebindPlaceholders(sql string, name func(i int) string) string {
+	var sb strings.Builder
+	inQuote := false
+	count := 0
+	for i := 0; i < len(sql); i++ {
+		switch c := sql[i]; {
+		case c == '\'':
+			inQuote = !inQuote
+			sb.WriteByte(c)
+		case c == '?' && !inQuote:
+			count++
+			sb.WriteString(name(count))
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
+
+/*
+BuildWithDialect is a variant of Build for callers who want dialect-correct SQL (placeholder
+syntax and LIKE concatenation) in a single call, instead of calling Build then Rebind
+separately. It's exactly `filter, invalidParams, err := Build(...)` followed by
+`filter.SqlFilters = Rebind(filter.SqlFilters, dialect)`.
+*/
+func BuildWithDialect(filterAllowedColumnNames AllowedColumns, getParams url.Values, dialect Dialect) (Filter, url.Values, error) {
+	filter, invalidParams, err := Build(filterAllowedColumnNames, getParams)
+	if err != nil {
+		return Filter{}, invalidParams, err
+	}
+	filter.SqlFilters = Rebind(filter.SqlFilters, dialect)
+	return filter, invalidParams, nil
+}
+
+/*
+BuildNamed is a variant of Build for database drivers that bind by name rather than by
+position (e.g. sqlx named queries). Instead of `?` placeholders and a []any of values in
+positional order, it returns SQL using `:col1_0`, `:col1_1`, ... placeholders (suffixed
+with an index to disambiguate multi-value operators like IN) and a map[string]any from
+placeholder name to value.
+*/
+func BuildNamed(filterAllowedColumnNames AllowedColumns, getParams url.Values) (string, map[string]any, url.Values, error) {
+	orderedGetParams, err := getOrderedGetParams(getParams)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	validParams, invalidParams, err := getValidQueryParams(filterAllowedColumnNames, orderedGetParams)
+	if err != nil {
+		return "", nil, invalidParams, err
+	}
+	sql, namedValues, err := buildNamedQueryFilters(validParams)
+	if err != nil {
+		return "", nil, invalidParams, err
+	}
+	return sql, namedValues, invalidParams, nil
+}
+
+/*
+Accepts alphabetically sorted ordered map which contains valid "<original get parameter
+name>: <filterParam>" pairs (see getValidQueryParams), same as buildQueryFilters (including
+its Group bucketing into OR-joined, paren-wrapped groups), but emits `:col_0`-style named
+placeholders instead of `?`.
+*/
+func buildNamedQueryFilters(validParams *omap.Omap[string, filterParam]) (string, map[string]any, error) {
+	buckets, err := omap.New[string, []filterParam]()
+	if err != nil {
+		return "", nil, err
+	}
+	for _, mapPair := range validParams.Pairs() {
+		fp := mapPair.Value
+		bucketKey := fp.Group
+		if bucketKey == "" {
+			// See buildQueryFilters: keyed by the original get parameter name so two
+			// operators on the same column stay in separate, AND-joined buckets.
+			bucketKey = ungroupedBucketKeyPrefix + mapPair.Key
+		}
+		members, _ := buckets.Get(bucketKey)
+		buckets.Set(bucketKey, append(members, fp))
+	}
+
+	namedValues := map[string]any{}
+	var queryFilterGroups []string
+	usedNamePrefixes := make(map[string]bool)
+	for _, bucketPair := range buckets.Pairs() {
+		members := bucketPair.Value
+		memberFilters := make([]string, 0, len(members))
+		for _, fp := range members {
+			placeholderValues, err := fp.PlaceholderValues()
+			if err != nil {
+				return "", nil, err
+			}
+			// Disambiguate named placeholders when the same column is addressed more
+			// than once (e.g. "age__gt"/"age__lt"), since both would otherwise want
+			// the "age_0" name for their first value. Checked against every prefix
+			// used so far (not just fp.Name's own occurrences), so the disambiguated
+			// suffix can't collide with another whitelisted column's plain name.
+			namePrefix := fp.Name
+			for n := 1; usedNamePrefixes[namePrefix]; n++ {
+				namePrefix = fmt.Sprintf("%s_%d", fp.Name, n)
+			}
+			usedNamePrefixes[namePrefix] = true
+			namedSql := rebindPlaceholders(fp.Sql(), func(i int) string {
+				name := fmt.Sprintf("%s_%d", namePrefix, i-1)
+				namedValues[name] = placeholderValues[i-1]
+				return ":" + name
+			})
+			memberFilters = append(memberFilters, namedSql)
+		}
+		groupFilter := strings.Join(memberFilters, " OR ")
+		if len(members) > 1 {
+			groupFilter = "(" + groupFilter + ")"
+		}
+		queryFilterGroups = append(queryFilterGroups, groupFilter)
+	}
+	return strings.Join(queryFilterGroups, " AND "), namedValues, nil
+}
@@ -28,3 +28,52 @@ type ErrWrongOperator struct {
 func (e ErrWrongOperator) Error() string {
 	return fmt.Sprintf("wrong filtering operator name '%v' in parameter '%v' ", e.Operator, e.ParamName)
 }
+
+// This error is returned by Build (and BuildFromFilterExpression) when a query parameter
+// value can't be coerced to its column's declared ColumnType, e.g. col__gt=notanumber on
+// a column registered with ColumnTypeInt.
+type ErrInvalidValue struct {
+	Column string
+	Raw    string
+	Want   string
+}
+
+func (e ErrInvalidValue) Error() string {
+	return fmt.Sprintf("invalid value %q for column '%v': want %v", e.Raw, e.Column, e.Want)
+}
+
+// This error is returned by Build (and BuildNamed) when the same column is addressed by
+// two query params tagged with different OR-group tags, e.g. "col1.g1=a&col1.g2=b",
+// since it's ambiguous which group col1's filter should join.
+type ErrConflictingFilterGroups struct {
+	Column string
+	Group1 string
+	Group2 string
+}
+
+func (e ErrConflictingFilterGroups) Error() string {
+	return fmt.Sprintf("column '%v' addressed with conflicting filter groups %q and %q", e.Column, e.Group1, e.Group2)
+}
+
+// This error is returned by BuildWithStyle when a column is addressed both as a bare
+// scalar (e.g. "col=val") and as an explicit array (e.g. "col[]=val") in the same
+// getParams, since it's ambiguous which form the caller meant.
+type ErrAmbiguousArray struct {
+	Column string
+}
+
+func (e ErrAmbiguousArray) Error() string {
+	return fmt.Sprintf("column '%v' addressed both as a scalar and as an explicit array", e.Column)
+}
+
+// This error is returned by BuildFromFilterExpression when the SCIM filter expression
+// passed to it can't be parsed, e.g. an unknown attribute, operator or unbalanced parens.
+// Pos is the byte offset into the expression string where the problem was found.
+type ErrInvalidFilterExpression struct {
+	Pos int
+	Msg string
+}
+
+func (e ErrInvalidFilterExpression) Error() string {
+	return fmt.Sprintf("invalid filter expression at position %d: %v", e.Pos, e.Msg)
+}
@@ -9,8 +9,8 @@ import (
 
 func Example() {
 	var whitelist = naivequeryfilters.AllowedColumns{
-		"col1": func(s string) string { return "table_name.col1" },
-		"col2": func(s string) string { return s },
+		"col1": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return "table_name.col1" }},
+		"col2": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
 	}
 	filter, invalidParams, err := naivequeryfilters.Build(whitelist, url.Values{
 		"col1__in":     []string{"1", "2"},
@@ -3,14 +3,23 @@
 // Supported features:
 //
 //   - IN and NOT IN for list (or not) of get parameters: `.../path?column__in=1&column__in=2&column__in=3“
-//   - exact equality and inequality (using not_in): `.../path?column__not_in=1 = column <> 1`
+//   - exact equality and inequality (using not_in or ne): `.../path?column__not_in=1 = column <> 1`
 //   - LIKE using __like operator: col1__like=foo = `LOWER(col1) LIKE CONCAT('%', foo, '%')`
-//
-// Planned features:
-//
-//   - support for operators: BETWEEN, IS NULL, gt, gte, lt, lte, not
-//   - OR support
-//   - ordering support (parentheses in resulting SQL)
+//   - __startswith / __endswith: anchored variants of LIKE
+//   - comparison operators: __gt, __gte, __lt, __lte
+//   - __between: `col1__between=1&col1__between=10` = `col1 BETWEEN ? AND ?`
+//   - __isnull: `col1__isnull=true` = `col1 IS NULL`, `col1__isnull=false` = `col1 IS NOT NULL`
+//   - per-column value types (AllowedColumns.Type) with placeholder value coercion
+//   - pluggable SQL dialects (Rebind, BuildWithDialect, BuildNamed) for
+//     Postgres/SQLite/SQLServer/named placeholders
+//   - OR groups: `col1__in.g1=1&col2.g1=2` = `(col1 IN (1) OR col2 = 2)`, joined by AND with
+//     ungrouped params and other groups; single-member groups emit without parens
+//   - __approx / __ilike: fuzzy match, portable LIKE fallback by default, ILIKE on
+//     DialectPostgres (see Rebind), or a pg_trgm similarity predicate via Filter.WithTrigram
+//   - BuildWithStyle: qs/Rails/Gin-style `col[op]=val` / `col[]=val` get parameters, as an
+//     alternative to this package's native `col__op=val` style
+//   - BuildFromFilterExpression: SCIM 2.0 filter expression strings (`eq`/`and`/`or`/parens),
+//     as an alternative front-end to the get-parameter-based Build/BuildWithStyle
 package naivequeryfilters
 
 import (
@@ -18,7 +27,9 @@ import (
 	"maps"
 	"net/url"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/kirill-scherba/omap"
 )
@@ -28,14 +39,36 @@ These constants define operator names in get parameters keys.
 E.g. ".../path?column__not_in=val" == fmt.Sprintf(".../path?column%s%s=val", OPERATOR_SEPARATOR, OPERATOR_NOT_IN)
 */
 const (
-	OPERATOR_SEPARATOR = "__"
-	OPERATOR_IN        = "in"
-	OPERATOR_NOT_IN    = "not_in"
-	OPERATOR_LIKE      = "like"
+	// GROUP_SEPARATOR separates a filter param's operator (or column name, for the
+	// no-operator case) from an optional OR-group tag.
+	// E.g. "col1__in.g1" groups col1's IN filter into group "g1"; "col2.g1" groups
+	// col2's equality filter into the same group.
+	GROUP_SEPARATOR = "."
+)
+
+const (
+	OPERATOR_SEPARATOR  = "__"
+	OPERATOR_IN         = "in"
+	OPERATOR_NOT_IN     = "not_in"
+	OPERATOR_LIKE       = "like"
+	OPERATOR_NE         = "ne"
+	OPERATOR_GT         = "gt"
+	OPERATOR_GTE        = "gte"
+	OPERATOR_LT         = "lt"
+	OPERATOR_LTE        = "lte"
+	OPERATOR_BETWEEN    = "between"
+	OPERATOR_ISNULL     = "isnull"
+	OPERATOR_STARTSWITH = "startswith"
+	OPERATOR_ENDSWITH   = "endswith"
+	OPERATOR_APPROX     = "approx"
+	OPERATOR_ILIKE      = "ilike" // alias of OPERATOR_APPROX
 )
 
 /*
 These constants define SQL query tokens in which operators will be transformed.
+QUERY_TOKEN_ISNULL, QUERY_TOKEN_STARTSWITH and QUERY_TOKEN_ENDSWITH are not themselves
+valid SQL, they're internal dispatch tokens filterParam.Sql() recognizes to emit the
+right SQL shape (same trick QUERY_TOKEN_LIKE already used for the CONCAT(...) shape).
 */
 const (
 	QUERY_TOKEN_IN         = "IN"
@@ -43,15 +76,35 @@ const (
 	QUERY_TOKEN_EQUALS     = "="
 	QUERY_TOKEN_NOT_EQUALS = "<>"
 	QUERY_TOKEN_LIKE       = "LIKE"
+	QUERY_TOKEN_GT         = ">"
+	QUERY_TOKEN_GTE        = ">="
+	QUERY_TOKEN_LT         = "<"
+	QUERY_TOKEN_LTE        = "<="
+	QUERY_TOKEN_BETWEEN    = "BETWEEN"
+	QUERY_TOKEN_ISNULL     = "ISNULL"
+	QUERY_TOKEN_STARTSWITH = "STARTSWITH"
+	QUERY_TOKEN_ENDSWITH   = "ENDSWITH"
+	QUERY_TOKEN_APPROX     = "APPROX"
 )
 
 /*
 This map stores "<get param operator>: <sql query token equivalent>" pairs.
 */
 var OPERATORS = map[string]string{
-	OPERATOR_IN:     QUERY_TOKEN_IN,
-	OPERATOR_NOT_IN: QUERY_TOKEN_NOT_IN,
-	OPERATOR_LIKE:   QUERY_TOKEN_LIKE,
+	OPERATOR_IN:         QUERY_TOKEN_IN,
+	OPERATOR_NOT_IN:     QUERY_TOKEN_NOT_IN,
+	OPERATOR_LIKE:       QUERY_TOKEN_LIKE,
+	OPERATOR_NE:         QUERY_TOKEN_NOT_EQUALS,
+	OPERATOR_GT:         QUERY_TOKEN_GT,
+	OPERATOR_GTE:        QUERY_TOKEN_GTE,
+	OPERATOR_LT:         QUERY_TOKEN_LT,
+	OPERATOR_LTE:        QUERY_TOKEN_LTE,
+	OPERATOR_BETWEEN:    QUERY_TOKEN_BETWEEN,
+	OPERATOR_ISNULL:     QUERY_TOKEN_ISNULL,
+	OPERATOR_STARTSWITH: QUERY_TOKEN_STARTSWITH,
+	OPERATOR_ENDSWITH:   QUERY_TOKEN_ENDSWITH,
+	OPERATOR_APPROX:     QUERY_TOKEN_APPROX,
+	OPERATOR_ILIKE:      QUERY_TOKEN_APPROX,
 }
 
 /*
@@ -63,19 +116,61 @@ type Filter struct {
 
 	// List of placeholder values for SQL query.
 	PlaceholderValues []interface{}
+
+	// Preamble holds out-of-band SQL statements that callers must execute before
+	// SqlFilters, in order. Populated by Filter options such as WithTrigram. Empty for
+	// a plain Build/BuildNamed result.
+	Preamble []string
+
+	// PreambleValues holds Preamble's placeholder values, one slice per Preamble
+	// statement at the same index.
+	PreambleValues [][]any
 }
 
 /*
-Map storing "<db column name>: <db column name changing function>".
+ColumnType declares the Go type backing a column's values, so Build can coerce query
+parameter strings to it instead of always emitting string placeholder values.
+The zero value, ColumnTypeString, keeps values as strings.
+*/
+type ColumnType int
 
-Matching every column name to function which will optionally return transformed (or completely new) name instead of passed.
-Primarily used for transforming of aliases like this: "SELECT table.col_name AS new_col_name" into
+const (
+	ColumnTypeString ColumnType = iota
+	ColumnTypeInt
+	ColumnTypeFloat
+	ColumnTypeBool
+	ColumnTypeTime
+)
+
+/*
+ColumnConfig is the whitelist entry for a single column: a rename function plus the
+column's declared value type. See AllowedColumns for why Rename exists and ColumnType
+for how Type affects placeholder values.
+*/
+type ColumnConfig struct {
+	// Rename optionally returns a transformed (or completely new) name instead of the
+	// column name passed in.
+	Rename func(string) string
+
+	// Type is the column's declared Go type. Build coerces that column's placeholder
+	// values to it, returning ErrInvalidValue if a value doesn't parse as Type.
+	// Defaults to ColumnTypeString.
+	Type ColumnType
+}
+
+/*
+Map storing "<db column name>: <column config>".
+
+Matching every column name to a ColumnConfig whose Rename function will optionally return
+transformed (or completely new) name instead of passed, and whose Type declares the Go
+type placeholder values for that column should be coerced to.
+Rename is primarily used for transforming of aliases like this: "SELECT table.col_name AS new_col_name" into
 column names like this: "table_name.column_name" to be used in resulting SQL query.
 
 We need that because inside SQL we can't use column aliases inside WHERE conditions:
 https://stackoverflow.com/questions/13031013/how-do-i-use-alias-in-where-clause
 */
-type AllowedColumns map[string]func(string) string
+type AllowedColumns map[string]ColumnConfig
 
 /*
 filterParam holds query parameter name with query token in separate fields also with
@@ -95,12 +190,23 @@ type filterParam struct {
 	// Holds query param values when array is passed inside URL.
 	//E.g. ".../path?column__in=1&column__in=2&column__in=3"
 	Values []string
+
+	// Holds the declared Go type of the column this filterParam belongs to, used to
+	// coerce Values into typed placeholder values. Set by getValidQueryParams once the
+	// column is resolved against AllowedColumns; defaults to ColumnTypeString.
+	Type ColumnType
+
+	// Holds the OR-group tag this filterParam belongs to, parsed off the query param
+	// name by GROUP_SEPARATOR. Empty when the param wasn't grouped. filterParams
+	// sharing a non-empty Group are joined by OR and wrapped in parens by
+	// buildQueryFilters; different groups (and ungrouped params) are joined by AND.
+	Group string
 }
 
 /*
-Construct filterParam based on column name (without filter operator), operator and values (from url) array.
+Construct filterParam based on column name (without filter operator), operator, group tag and values (from url) array.
 */
-func newFilterParam(name, operator string, values []string) (filterParam, error) {
+func newFilterParam(name, operator, group string, values []string) (filterParam, error) {
 	if len(values) == 0 {
 		return filterParam{}, fmt.Errorf("passed empty values to filterParam")
 	}
@@ -109,9 +215,22 @@ func newFilterParam(name, operator string, values []string) (filterParam, error)
 			Name:       name,
 			QueryToken: QUERY_TOKEN_IN,
 			Values:     values,
+			Group:      group,
 		}, nil
-	} else if operator == OPERATOR_LIKE && len(values) > 1 {
-		return filterParam{}, fmt.Errorf("like operator only supports single value")
+	}
+	switch operator {
+	case OPERATOR_LIKE, OPERATOR_NE, OPERATOR_STARTSWITH, OPERATOR_ENDSWITH, OPERATOR_ISNULL, OPERATOR_APPROX, OPERATOR_ILIKE,
+		OPERATOR_GT, OPERATOR_GTE, OPERATOR_LT, OPERATOR_LTE:
+		if len(values) > 1 {
+			return filterParam{}, fmt.Errorf("%s operator only supports single value", operator)
+		}
+	case OPERATOR_BETWEEN:
+		if len(values) != 2 {
+			return filterParam{}, fmt.Errorf("between operator requires exactly two values")
+		}
+	}
+	if operator == OPERATOR_ISNULL && values[0] != "true" && values[0] != "false" {
+		return filterParam{}, fmt.Errorf("isnull operator only accepts 'true' or 'false', got %q", values[0])
 	}
 	queryToken, ok := OPERATORS[operator]
 	if !ok {
@@ -121,6 +240,7 @@ func newFilterParam(name, operator string, values []string) (filterParam, error)
 		Name:       name,
 		QueryToken: queryToken,
 		Values:     values,
+		Group:      group,
 	}, nil
 }
 
@@ -128,18 +248,94 @@ func newFilterParam(name, operator string, values []string) (filterParam, error)
 Get SQL query code for filterParam.
 */
 func (fp *filterParam) Sql() string {
-	if len(fp.Values) == 1 && fp.QueryToken == QUERY_TOKEN_IN {
-		return fmt.Sprintf("%s %s ?", fp.Name, QUERY_TOKEN_EQUALS)
-	}
-	if len(fp.Values) == 1 && fp.QueryToken == QUERY_TOKEN_NOT_IN {
-		return fmt.Sprintf("%s %s ?", fp.Name, QUERY_TOKEN_NOT_EQUALS)
-	}
-	if fp.QueryToken == QUERY_TOKEN_LIKE {
+	switch fp.QueryToken {
+	case QUERY_TOKEN_IN:
+		if len(fp.Values) == 1 {
+			return fmt.Sprintf("%s %s ?", fp.Name, QUERY_TOKEN_EQUALS)
+		}
+	case QUERY_TOKEN_NOT_IN:
+		if len(fp.Values) == 1 {
+			return fmt.Sprintf("%s %s ?", fp.Name, QUERY_TOKEN_NOT_EQUALS)
+		}
+	case QUERY_TOKEN_LIKE:
 		return fmt.Sprintf("LOWER(%s) %s CONCAT('%%', ?, '%%')", fp.Name, QUERY_TOKEN_LIKE)
+	case QUERY_TOKEN_STARTSWITH:
+		return fmt.Sprintf("LOWER(%s) %s CONCAT(?, '%%')", fp.Name, QUERY_TOKEN_LIKE)
+	case QUERY_TOKEN_ENDSWITH:
+		return fmt.Sprintf("LOWER(%s) %s CONCAT('%%', ?)", fp.Name, QUERY_TOKEN_LIKE)
+	case QUERY_TOKEN_APPROX:
+		// Portable fallback; Rebind rewrites this to `%s ILIKE '%%' || ? || '%%'` on
+		// DialectPostgres, and Filter.WithTrigram rewrites either shape to a pg_trgm
+		// `%s %% ?` similarity predicate.
+		return fmt.Sprintf("LOWER(%s) %s CONCAT('%%', LOWER(?), '%%')", fp.Name, QUERY_TOKEN_LIKE)
+	case QUERY_TOKEN_BETWEEN:
+		return fmt.Sprintf("%s %s ? AND ?", fp.Name, QUERY_TOKEN_BETWEEN)
+	case QUERY_TOKEN_ISNULL:
+		if fp.Values[0] == "true" {
+			return fmt.Sprintf("%s IS NULL", fp.Name)
+		}
+		return fmt.Sprintf("%s IS NOT NULL", fp.Name)
+	case QUERY_TOKEN_GT, QUERY_TOKEN_GTE, QUERY_TOKEN_LT, QUERY_TOKEN_LTE, QUERY_TOKEN_NOT_EQUALS:
+		return fmt.Sprintf("%s %s ?", fp.Name, fp.QueryToken)
 	}
 	return fp.buildPhrase(fp.Name, fp.QueryToken, fp.Values)
 }
 
+/*
+PlaceholderValues coerces fp.Values to fp.Type and returns them as the placeholder
+values fp.Sql() expects, in order. Returns no values for QUERY_TOKEN_ISNULL, which
+emits no placeholders at all.
+*/
+func (fp *filterParam) PlaceholderValues() ([]any, error) {
+	if fp.QueryToken == QUERY_TOKEN_ISNULL {
+		return nil, nil
+	}
+	values := make([]any, len(fp.Values))
+	for i, raw := range fp.Values {
+		coerced, err := coerceValue(raw, fp.Type, fp.Name)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = coerced
+	}
+	return values, nil
+}
+
+/*
+Parse raw into the Go type declared by columnType, returning ErrInvalidValue if raw
+doesn't parse as that type. ColumnTypeString (the zero value) always succeeds.
+*/
+func coerceValue(raw string, columnType ColumnType, columnName string) (any, error) {
+	switch columnType {
+	case ColumnTypeInt:
+		value, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return nil, ErrInvalidValue{Column: columnName, Raw: raw, Want: "int"}
+		}
+		return value, nil
+	case ColumnTypeFloat:
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, ErrInvalidValue{Column: columnName, Raw: raw, Want: "float"}
+		}
+		return value, nil
+	case ColumnTypeBool:
+		value, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, ErrInvalidValue{Column: columnName, Raw: raw, Want: "bool"}
+		}
+		return value, nil
+	case ColumnTypeTime:
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return nil, ErrInvalidValue{Column: columnName, Raw: raw, Want: "time"}
+		}
+		return value, nil
+	default:
+		return raw, nil
+	}
+}
+
 /*
 Build SQL phrase for multi-value operators. (IN, NOT IN)
 */
@@ -166,7 +362,11 @@ func Build(filterAllowedColumnNames AllowedColumns, getParams url.Values) (Filte
 	if err != nil {
 		return Filter{}, invalidParams, err
 	}
-	return buildQueryFilters(validParams), invalidParams, nil
+	filter, err := buildQueryFilters(validParams)
+	if err != nil {
+		return Filter{}, invalidParams, err
+	}
+	return filter, invalidParams, nil
 }
 
 /*
@@ -191,8 +391,15 @@ func getOrderedGetParams(getParams url.Values) (*omap.Omap[string, []string], er
 
 /*
 Accepts whitelist of table columns and map of alphabetically sorted get params with values.
-Returns alphabetically sorted map of "<db column name>: <filterParam struct>" which contains only valid column names,
-wrong get parameters map (map with columns that are not in whitelist) and error.
+Returns alphabetically sorted map of "<original get parameter name>: <filterParam struct>"
+which contains only valid column names, wrong get parameters map (map with columns that are
+not in whitelist) and error.
+
+Keyed by the original get parameter name (not the resolved column name) so that a column
+addressed by two different operators, e.g. "age__gt=18&age__lt=65", keeps both filterParams
+instead of the second silently overwriting the first; buildQueryFilters/buildNamedQueryFilters
+then AND-join them like any other pair of ungrouped filterParams. A column is still only
+allowed to belong to one OR-group at a time, tracked separately via columnGroups below.
 */
 func getValidQueryParams(filterAllowedColumnNames AllowedColumns, getParams *omap.Omap[string, []string]) (*omap.Omap[string, filterParam], url.Values, error) {
 	validParams, err := omap.New[string, filterParam]()
@@ -204,6 +411,7 @@ func getValidQueryParams(filterAllowedColumnNames AllowedColumns, getParams *oma
 	}
 
 	removedParams := make(url.Values)
+	columnGroups := make(map[string]string)
 	for _, querystringPair := range getParams.Pairs() {
 		querystringFilterParamName := querystringPair.Key
 		filterParamValues := querystringPair.Value
@@ -212,9 +420,18 @@ func getValidQueryParams(filterAllowedColumnNames AllowedColumns, getParams *oma
 			return validParams, removedParams, err
 		}
 
-		if colNameConverterFunc, isColumnNameValid := filterAllowedColumnNames[filterParam.Name]; isColumnNameValid {
-			filterParam.Name = colNameConverterFunc(filterParam.Name)
-			validParams.Set(filterParam.Name, filterParam)
+		if colConfig, isColumnNameValid := filterAllowedColumnNames[filterParam.Name]; isColumnNameValid {
+			filterParam.Name = colConfig.Rename(filterParam.Name)
+			filterParam.Type = colConfig.Type
+			if existingGroup, alreadySeen := columnGroups[filterParam.Name]; alreadySeen && existingGroup != filterParam.Group {
+				return validParams, removedParams, ErrConflictingFilterGroups{
+					Column: filterParam.Name,
+					Group1: existingGroup,
+					Group2: filterParam.Group,
+				}
+			}
+			columnGroups[filterParam.Name] = filterParam.Group
+			validParams.Set(querystringFilterParamName, filterParam)
 		} else {
 			removedParams[querystringFilterParamName] = filterParamValues
 		}
@@ -231,14 +448,17 @@ func getValidQueryParams(filterAllowedColumnNames AllowedColumns, getParams *oma
 
 /*
 Accepts query param name from url and array of param values.
-Returns filterParam with separated column name and SQL query token and error.
+Returns filterParam with separated column name, SQL query token and OR-group tag, and error.
 */
 func splitParamName(paramName string, paramValues []string) (filterParam, error) {
-	res := strings.Split(paramName, OPERATOR_SEPARATOR)
-	if !strings.Contains(paramName, OPERATOR_SEPARATOR) {
+	base, group := splitGroupTag(paramName)
+
+	res := strings.Split(base, OPERATOR_SEPARATOR)
+	if !strings.Contains(base, OPERATOR_SEPARATOR) {
 		return newFilterParam(
 			res[0],
 			"",
+			group,
 			paramValues,
 		)
 	}
@@ -254,32 +474,75 @@ func splitParamName(paramName string, paramValues []string) (filterParam, error)
 			ParamName: paramName,
 		}
 	}
-	return newFilterParam(res[0], res[1], paramValues)
+	return newFilterParam(res[0], res[1], group, paramValues)
 }
 
 /*
-Accepts alphabetically sorted ordered map which contains valid "<db column name>: <filterParam>" pairs.
+Splits an optional trailing GROUP_SEPARATOR-delimited OR-group tag off paramName, e.g.
+"col1__in.g1" -> ("col1__in", "g1"), "col3" -> ("col3", "").
 */
-func buildQueryFilters(validParams *omap.Omap[string, filterParam]) Filter {
-	queryFilters := `` // Example value: `col1=? AND col2=?`
-	queryParamsValues := []any{}
-	filtersCount := 0
+func splitGroupTag(paramName string) (base, group string) {
+	base, group, found := strings.Cut(paramName, GROUP_SEPARATOR)
+	if !found {
+		return paramName, ""
+	}
+	return base, group
+}
+
+/*
+Accepts alphabetically sorted ordered map which contains valid "<original get parameter
+name>: <filterParam>" pairs (see getValidQueryParams).
+Buckets filterParams by their Group tag (preserving first-occurrence order, via omap),
+joining same-group filterParams with OR and wrapping multi-member groups in parens, then
+joins every bucket (and any ungrouped filterParam, which forms its own single-member
+bucket) with AND.
+Returns an error if any filterParam's values fail to coerce to their column's declared Type.
+*/
+func buildQueryFilters(validParams *omap.Omap[string, filterParam]) (Filter, error) {
+	buckets, err := omap.New[string, []filterParam]()
+	if err != nil {
+		return Filter{}, err
+	}
 	for _, mapPair := range validParams.Pairs() {
-		queryFilters += mapPair.Value.Sql()
-		filtersCount += 1
-		if filtersCount < validParams.Len() {
-			queryFilters += " AND "
+		fp := mapPair.Value
+		bucketKey := fp.Group
+		if bucketKey == "" {
+			// Ungrouped filterParams never merge with each other: give each one a
+			// bucket of its own, keyed by its (unique) original get parameter name -
+			// two different operators on the same column (e.g. "age__gt"/"age__lt")
+			// must stay in separate, AND-joined buckets rather than being OR-joined.
+			bucketKey = ungroupedBucketKeyPrefix + mapPair.Key
 		}
-		queryParamsValues = append(queryParamsValues, func() []any {
-			interfaceSlice := make([]any, len(mapPair.Value.Values))
-			for i := range interfaceSlice {
-				interfaceSlice[i] = mapPair.Value.Values[i]
+		members, _ := buckets.Get(bucketKey)
+		buckets.Set(bucketKey, append(members, fp))
+	}
+
+	var queryFilterGroups []string
+	queryParamsValues := []any{}
+	for _, bucketPair := range buckets.Pairs() {
+		members := bucketPair.Value
+		memberFilters := make([]string, 0, len(members))
+		for _, fp := range members {
+			memberFilters = append(memberFilters, fp.Sql())
+			placeholderValues, err := fp.PlaceholderValues()
+			if err != nil {
+				return Filter{}, err
 			}
-			return interfaceSlice
-		}()...)
+			queryParamsValues = append(queryParamsValues, placeholderValues...)
+		}
+		groupFilter := strings.Join(memberFilters, " OR ")
+		if len(members) > 1 {
+			groupFilter = "(" + groupFilter + ")"
+		}
+		queryFilterGroups = append(queryFilterGroups, groupFilter)
 	}
+
 	return Filter{
-		queryFilters,
-		queryParamsValues,
-	}
+		SqlFilters:        strings.Join(queryFilterGroups, " AND "),
+		PlaceholderValues: queryParamsValues,
+	}, nil
 }
+
+// ungroupedBucketKeyPrefix prefixes the synthetic bucket key buildQueryFilters assigns
+// to an ungrouped filterParam, so it can't collide with a real (non-empty) Group tag.
+const ungroupedBucketKeyPrefix = "\x00"
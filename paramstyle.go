@@ -0,0 +1,136 @@
+package naivequeryfilters
+
+import (
+	"net/url"
+	"regexp"
+)
+
+/*
+ParamStyle selects the get-parameter naming convention BuildWithStyle accepts, since not
+every HTTP client serializes filters the way Django/DRF does.
+*/
+type ParamStyle int
+
+const (
+	// StyleDjango is the `col__op=val` convention Build already speaks (and the one
+	// BuildWithStyle falls back to, unchanged, when passed StyleDjango).
+	StyleDjango ParamStyle = iota
+
+	// StyleBracket accepts `col[op]=val` (operator in brackets) and `col[]=val`
+	// (explicit array, equivalent to the operator-less `col=val` form). Also accepts
+	// the qs/Rails nested-object convention `filter[col][op]=val` / `filter[col][]=val`,
+	// treating a leading "filter" token as a namespacing wrapper to strip — but only for
+	// 3-token keys, so a whitelisted column literally named "filter" addressed as
+	// `filter[op]=val` isn't misread as the nested form.
+	StyleBracket
+
+	// StyleRails is StyleBracket restricted to the `col[]=val` array marker: it has no
+	// equivalent for `col[op]=val`, matching how Rails itself only ever uses `[]` to
+	// mean "this is a list".
+	StyleRails
+)
+
+// filterWrapperToken is the leading bracket token StyleBracket/StyleRails strip to
+// support the qs/Rails nested-object convention `filter[col][op]=val`.
+const filterWrapperToken = "filter"
+
+// bracketKeyPattern splits a get-parameter key into its bare first segment and its
+// (possibly empty) run of bracketed segments, e.g. "col[a][b]" -> ("col", "[a][b]").
+var bracketKeyPattern = regexp.MustCompile(`^([^\[\]]+)((?:\[[^\[\]]*\])*)$`)
+
+// bracketSegmentPattern pulls the contents out of each "[...]" segment in turn.
+var bracketSegmentPattern = regexp.MustCompile(`\[([^\[\]]*)\]`)
+
+/*
+splitBracketKey splits a get-parameter key into its dot-free tokens, e.g.
+"col[in]" -> ["col", "in"], "col[]" -> ["col", ""], "col" -> ["col"],
+"filter[col][in]" -> ["filter", "col", "in"]. ok is false if key isn't validly bracketed
+(unbalanced or nested brackets).
+*/
+func splitBracketKey(key string) (tokens []string, ok bool) {
+	m := bracketKeyPattern.FindStringSubmatch(key)
+	if m == nil {
+		return nil, false
+	}
+	tokens = []string{m[1]}
+	for _, segment := range bracketSegmentPattern.FindAllStringSubmatch(m[2], -1) {
+		tokens = append(tokens, segment[1])
+	}
+	return tokens, true
+}
+
+/*
+normalizeParamStyle rewrites getParams from style's bracket convention into the internal
+`col__op=val` (StyleDjango) key shape Build's pipeline already understands, so
+BuildWithStyle can otherwise reuse getOrderedGetParams/getValidQueryParams/
+buildQueryFilters unchanged. Returns ErrWrongQueryParamName for malformed keys, and
+ErrAmbiguousArray when a column is addressed both as a bare scalar (`col=val`) and as an
+explicit array (`col[]=val`) in the same getParams.
+*/
+func normalizeParamStyle(getParams url.Values, style ParamStyle) (url.Values, error) {
+	if style == StyleDjango {
+		return getParams, nil
+	}
+
+	sawScalar := map[string]bool{}
+	sawArray := map[string]bool{}
+	normalized := make(url.Values, len(getParams))
+
+	for key, values := range getParams {
+		tokens, ok := splitBracketKey(key)
+		if !ok {
+			return nil, ErrWrongQueryParamName{ParamName: key}
+		}
+		// Only strip the "filter" wrapper for genuinely nested keys (3 tokens, e.g.
+		// "filter[col][op]"/"filter[col][]"). A 2-token key like "filter[op]" is left
+		// alone, since it's indistinguishable from a real whitelisted column named
+		// "filter" addressed with a bracketed operator.
+		if tokens[0] == filterWrapperToken && len(tokens) > 2 {
+			tokens = tokens[1:]
+		}
+
+		var column, operator string
+		switch len(tokens) {
+		case 1:
+			column = tokens[0]
+			sawScalar[column] = true
+		case 2:
+			column, operator = tokens[0], tokens[1]
+			switch {
+			case operator == "":
+				sawArray[column] = true
+			case style == StyleRails:
+				return nil, ErrWrongQueryParamName{ParamName: key}
+			}
+		default:
+			return nil, ErrWrongQueryParamName{ParamName: key}
+		}
+
+		normalizedKey := column
+		if operator != "" {
+			normalizedKey = column + OPERATOR_SEPARATOR + operator
+		}
+		normalized[normalizedKey] = append(normalized[normalizedKey], values...)
+	}
+
+	for column := range sawArray {
+		if sawScalar[column] {
+			return nil, ErrAmbiguousArray{Column: column}
+		}
+	}
+
+	return normalized, nil
+}
+
+/*
+BuildWithStyle is a variant of Build for HTTP clients that don't serialize filters in
+Django's `col__op=val` style: it first normalizes getParams from style's bracket
+convention, then runs the same whitelist/SQL-generation pipeline Build does.
+*/
+func BuildWithStyle(filterAllowedColumnNames AllowedColumns, getParams url.Values, style ParamStyle) (Filter, url.Values, error) {
+	normalizedGetParams, err := normalizeParamStyle(getParams, style)
+	if err != nil {
+		return Filter{}, nil, err
+	}
+	return Build(filterAllowedColumnNames, normalizedGetParams)
+}
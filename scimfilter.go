@@ -0,0 +1,404 @@
+package naivequeryfilters
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+These constants define operator keywords recognized inside a SCIM (RFC 7644 section 3.4.2.2)
+filter expression, e.g. `userName eq "bob"` or `active pr`.
+*/
+const (
+	SCIM_OPERATOR_EQ = "eq"
+	SCIM_OPERATOR_NE = "ne"
+	SCIM_OPERATOR_CO = "co"
+	SCIM_OPERATOR_SW = "sw"
+	SCIM_OPERATOR_EW = "ew"
+	SCIM_OPERATOR_GT = "gt"
+	SCIM_OPERATOR_GE = "ge"
+	SCIM_OPERATOR_LT = "lt"
+	SCIM_OPERATOR_LE = "le"
+	SCIM_OPERATOR_PR = "pr"
+)
+
+/*
+These constants define boolean keywords recognized between/before attribute expressions.
+*/
+const (
+	scimKeywordAnd = "and"
+	scimKeywordOr  = "or"
+	scimKeywordNot = "not"
+)
+
+/*
+scimTokenKind enumerates the kinds of lexical tokens produced by scimLexer.
+*/
+type scimTokenKind int
+
+const (
+	scimTokenEOF scimTokenKind = iota
+	scimTokenIdent
+	scimTokenString
+	scimTokenNumber
+	scimTokenLParen
+	scimTokenRParen
+)
+
+/*
+scimToken is a single lexical token together with its byte offset in the source
+expression, used to build ErrInvalidFilterExpression.Pos on failure.
+*/
+type scimToken struct {
+	Kind scimTokenKind
+	Text string
+	Pos  int
+}
+
+/*
+scimLexer splits a SCIM filter expression into scimTokens. It is a small hand-rolled
+scanner, not a general purpose tokenizer: it only knows about the constructs a SCIM
+filter expression can contain (identifiers/keywords, quoted strings, bare numbers and
+booleans, and parentheses).
+*/
+type scimLexer struct {
+	input string
+	pos   int
+}
+
+func newScimLexer(input string) *scimLexer {
+	return &scimLexer{input: input}
+}
+
+func (l *scimLexer) skipSpaces() {
+	for l.pos < len(l.input) && (l.input[l.pos] == ' ' || l.input[l.pos] == '\t') {
+		l.pos++
+	}
+}
+
+/*
+Next returns the next token in the input or an error if the input contains an
+unterminated string literal or an unexpected character.
+*/
+func (l *scimLexer) Next() (scimToken, error) {
+	l.skipSpaces()
+	if l.pos >= len(l.input) {
+		return scimToken{Kind: scimTokenEOF, Pos: l.pos}, nil
+	}
+
+	start := l.pos
+	switch c := l.input[l.pos]; {
+	case c == '(':
+		l.pos++
+		return scimToken{Kind: scimTokenLParen, Text: "(", Pos: start}, nil
+	case c == ')':
+		l.pos++
+		return scimToken{Kind: scimTokenRParen, Text: ")", Pos: start}, nil
+	case c == '"':
+		return l.readString(start)
+	case isScimIdentStart(c):
+		for l.pos < len(l.input) && isScimIdentPart(l.input[l.pos]) {
+			l.pos++
+		}
+		return scimToken{Kind: scimTokenIdent, Text: l.input[start:l.pos], Pos: start}, nil
+	case isScimNumberStart(c):
+		for l.pos < len(l.input) && isScimNumberPart(l.input[l.pos]) {
+			l.pos++
+		}
+		return scimToken{Kind: scimTokenNumber, Text: l.input[start:l.pos], Pos: start}, nil
+	default:
+		return scimToken{}, ErrInvalidFilterExpression{Pos: start, Msg: fmt.Sprintf("unexpected character %q", c)}
+	}
+}
+
+func (l *scimLexer) readString(start int) (scimToken, error) {
+	var sb strings.Builder
+	l.pos++ // skip opening quote
+	for {
+		if l.pos >= len(l.input) {
+			return scimToken{}, ErrInvalidFilterExpression{Pos: start, Msg: "unterminated string literal"}
+		}
+		c := l.input[l.pos]
+		if c == '"' {
+			l.pos++
+			return scimToken{Kind: scimTokenString, Text: sb.String(), Pos: start}, nil
+		}
+		if c == '\\' && l.pos+1 < len(l.input) && l.input[l.pos+1] == '"' {
+			sb.WriteByte('"')
+			l.pos += 2
+			continue
+		}
+		sb.WriteByte(c)
+		l.pos++
+	}
+}
+
+func isScimIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isScimIdentPart(c byte) bool {
+	return isScimIdentStart(c) || c == '.' || c == ':' || (c >= '0' && c <= '9')
+}
+
+func isScimNumberStart(c byte) bool {
+	return c == '-' || (c >= '0' && c <= '9')
+}
+
+func isScimNumberPart(c byte) bool {
+	return (c >= '0' && c <= '9') || c == '.'
+}
+
+/*
+scimOperators maps a lowercased SCIM compare operator keyword to the SQL token it
+emits. `pr` is handled separately since it takes no comparison value.
+*/
+var scimOperators = map[string]string{
+	SCIM_OPERATOR_EQ: QUERY_TOKEN_EQUALS,
+	SCIM_OPERATOR_NE: QUERY_TOKEN_NOT_EQUALS,
+	SCIM_OPERATOR_GT: ">",
+	SCIM_OPERATOR_GE: ">=",
+	SCIM_OPERATOR_LT: "<",
+	SCIM_OPERATOR_LE: "<=",
+}
+
+/*
+scimFilterParser turns a flat token stream into parameterized SQL, resolving attribute
+names through the same AllowedColumns whitelist Build uses.
+*/
+type scimFilterParser struct {
+	tokens  []scimToken
+	pos     int
+	allowed AllowedColumns
+}
+
+func newScimFilterParser(expr string, allowed AllowedColumns) (*scimFilterParser, error) {
+	lexer := newScimLexer(expr)
+	tokens := make([]scimToken, 0)
+	for {
+		tok, err := lexer.Next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.Kind == scimTokenEOF {
+			break
+		}
+	}
+	return &scimFilterParser{tokens: tokens, allowed: allowed}, nil
+}
+
+func (p *scimFilterParser) peek() scimToken {
+	return p.tokens[p.pos]
+}
+
+func (p *scimFilterParser) advance() scimToken {
+	tok := p.tokens[p.pos]
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+/*
+peekKeyword reports whether the current token is an identifier equal (case insensitively)
+to keyword, which is how "and"/"or"/"not"/operators are recognized without reserving them
+as a separate token kind.
+*/
+func (p *scimFilterParser) peekKeyword(keyword string) bool {
+	tok := p.peek()
+	return tok.Kind == scimTokenIdent && strings.EqualFold(tok.Text, keyword)
+}
+
+func (p *scimFilterParser) parse() (string, []any, error) {
+	sql, values, err := p.parseOrExpr()
+	if err != nil {
+		return "", nil, err
+	}
+	if p.peek().Kind != scimTokenEOF {
+		return "", nil, ErrInvalidFilterExpression{Pos: p.peek().Pos, Msg: fmt.Sprintf("unexpected token %q", p.peek().Text)}
+	}
+	return sql, values, nil
+}
+
+func (p *scimFilterParser) parseOrExpr() (string, []any, error) {
+	sql, values, err := p.parseAndExpr()
+	if err != nil {
+		return "", nil, err
+	}
+	for p.peekKeyword(scimKeywordOr) {
+		p.advance()
+		rightSql, rightValues, err := p.parseAndExpr()
+		if err != nil {
+			return "", nil, err
+		}
+		sql = fmt.Sprintf("%s OR %s", sql, rightSql)
+		values = append(values, rightValues...)
+	}
+	return sql, values, nil
+}
+
+func (p *scimFilterParser) parseAndExpr() (string, []any, error) {
+	sql, values, err := p.parseNotExpr()
+	if err != nil {
+		return "", nil, err
+	}
+	for p.peekKeyword(scimKeywordAnd) {
+		p.advance()
+		rightSql, rightValues, err := p.parseNotExpr()
+		if err != nil {
+			return "", nil, err
+		}
+		sql = fmt.Sprintf("%s AND %s", sql, rightSql)
+		values = append(values, rightValues...)
+	}
+	return sql, values, nil
+}
+
+func (p *scimFilterParser) parseNotExpr() (string, []any, error) {
+	if p.peekKeyword(scimKeywordNot) {
+		p.advance()
+		if p.peek().Kind != scimTokenLParen {
+			return "", nil, ErrInvalidFilterExpression{Pos: p.peek().Pos, Msg: "expected '(' after 'not'"}
+		}
+		p.advance()
+		sql, values, err := p.parseOrExpr()
+		if err != nil {
+			return "", nil, err
+		}
+		if p.peek().Kind != scimTokenRParen {
+			return "", nil, ErrInvalidFilterExpression{Pos: p.peek().Pos, Msg: "expected ')' to close 'not' group"}
+		}
+		p.advance()
+		return fmt.Sprintf("NOT (%s)", sql), values, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *scimFilterParser) parsePrimary() (string, []any, error) {
+	if p.peek().Kind == scimTokenLParen {
+		p.advance()
+		sql, values, err := p.parseOrExpr()
+		if err != nil {
+			return "", nil, err
+		}
+		if p.peek().Kind != scimTokenRParen {
+			return "", nil, ErrInvalidFilterExpression{Pos: p.peek().Pos, Msg: "expected ')'"}
+		}
+		p.advance()
+		return fmt.Sprintf("(%s)", sql), values, nil
+	}
+	return p.parseAttrExpr()
+}
+
+func (p *scimFilterParser) parseAttrExpr() (string, []any, error) {
+	attrTok := p.peek()
+	if attrTok.Kind != scimTokenIdent {
+		return "", nil, ErrInvalidFilterExpression{Pos: attrTok.Pos, Msg: fmt.Sprintf("expected attribute name, got %q", attrTok.Text)}
+	}
+	p.advance()
+
+	colConfig, isColumnValid := p.allowed[attrTok.Text]
+	if !isColumnValid {
+		return "", nil, ErrInvalidFilterExpression{Pos: attrTok.Pos, Msg: fmt.Sprintf("attribute %q is not allowed", attrTok.Text)}
+	}
+	columnName := colConfig.Rename(attrTok.Text)
+
+	opTok := p.peek()
+	if opTok.Kind != scimTokenIdent {
+		return "", nil, ErrInvalidFilterExpression{Pos: opTok.Pos, Msg: fmt.Sprintf("expected filter operator, got %q", opTok.Text)}
+	}
+	operator := strings.ToLower(opTok.Text)
+	p.advance()
+
+	if operator == SCIM_OPERATOR_PR {
+		return fmt.Sprintf("%s IS NOT NULL", columnName), nil, nil
+	}
+
+	switch operator {
+	case SCIM_OPERATOR_CO:
+		value, err := p.parseCompValue()
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("LOWER(%s) LIKE CONCAT('%%', ?, '%%')", columnName), []any{value}, nil
+	case SCIM_OPERATOR_SW:
+		value, err := p.parseCompValue()
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("LOWER(%s) LIKE CONCAT(?, '%%')", columnName), []any{value}, nil
+	case SCIM_OPERATOR_EW:
+		value, err := p.parseCompValue()
+		if err != nil {
+			return "", nil, err
+		}
+		return fmt.Sprintf("LOWER(%s) LIKE CONCAT('%%', ?)", columnName), []any{value}, nil
+	}
+
+	queryToken, isOperatorValid := scimOperators[operator]
+	if !isOperatorValid {
+		return "", nil, ErrInvalidFilterExpression{Pos: opTok.Pos, Msg: fmt.Sprintf("unknown filter operator %q", opTok.Text)}
+	}
+	rawValue, err := p.parseCompValue()
+	if err != nil {
+		return "", nil, err
+	}
+	coerced, err := coerceValue(fmt.Sprint(rawValue), colConfig.Type, attrTok.Text)
+	if err != nil {
+		return "", nil, err
+	}
+	return fmt.Sprintf("%s %s ?", columnName, queryToken), []any{coerced}, nil
+}
+
+/*
+parseCompValue consumes and returns the comparison value of an attrExp: a quoted
+string (already unescaped by the lexer) or a bare numeric/boolean literal.
+*/
+func (p *scimFilterParser) parseCompValue() (any, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case scimTokenString:
+		p.advance()
+		return tok.Text, nil
+	case scimTokenNumber:
+		p.advance()
+		return tok.Text, nil
+	case scimTokenIdent:
+		if strings.EqualFold(tok.Text, "true") || strings.EqualFold(tok.Text, "false") {
+			p.advance()
+			return tok.Text, nil
+		}
+		return nil, ErrInvalidFilterExpression{Pos: tok.Pos, Msg: fmt.Sprintf("expected comparison value, got identifier %q", tok.Text)}
+	default:
+		return nil, ErrInvalidFilterExpression{Pos: tok.Pos, Msg: fmt.Sprintf("expected comparison value, got %q", tok.Text)}
+	}
+}
+
+/*
+BuildFromFilterExpression is an alternative entry point to Build for callers that
+receive a single SCIM 2.0 (RFC 7644 section 3.4.2.2) filter expression string instead
+of Django-style `__` suffixed query parameters, e.g.:
+
+	userName eq "bob" and (emails co "@x" or active pr)
+
+Unlike Build, this supports arbitrary boolean grouping ("and"/"or"/"not" with
+parentheses), which the flat key=value query parameter shape cannot express.
+Every attribute name referenced in expr is resolved through allowed exactly like
+Build resolves query parameter names; an attribute that is not in allowed, or any
+other malformed input, is reported as ErrInvalidFilterExpression.
+*/
+func BuildFromFilterExpression(allowed AllowedColumns, expr string) (Filter, error) {
+	parser, err := newScimFilterParser(expr, allowed)
+	if err != nil {
+		return Filter{}, err
+	}
+	sql, values, err := parser.parse()
+	if err != nil {
+		return Filter{}, err
+	}
+	return Filter{
+		SqlFilters:        sql,
+		PlaceholderValues: values,
+	}, nil
+}
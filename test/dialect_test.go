@@ -0,0 +1,133 @@
+package naivequeryfilters_test
+
+import (
+	"net/url"
+	"testing"
+
+	naivequeryfilters "github.com/Wayodeni/go-naive-queryfilters"
+	"github.com/stretchr/testify/assert"
+)
+
+type rebindTestCase struct {
+	Sql      string
+	Dialect  naivequeryfilters.Dialect
+	Expected string
+}
+
+var rebindTestData = []rebindTestCase{
+	{
+		Sql:      `col1 IN (?,?) AND col2 = ?`,
+		Dialect:  naivequeryfilters.DialectMySQL,
+		Expected: `col1 IN (?,?) AND col2 = ?`,
+	},
+	{
+		Sql:      `col1 IN (?,?) AND col2 = ?`,
+		Dialect:  naivequeryfilters.DialectPostgres,
+		Expected: `col1 IN ($1,$2) AND col2 = $3`,
+	},
+	{
+		Sql:      `LOWER(col1) LIKE CONCAT('%', ?, '%')`,
+		Dialect:  naivequeryfilters.DialectPostgres,
+		Expected: `LOWER(col1) LIKE '%' || $1 || '%'`,
+	},
+	{
+		Sql:      `LOWER(col1) LIKE CONCAT('%', ?, '%')`,
+		Dialect:  naivequeryfilters.DialectSQLite,
+		Expected: `LOWER(col1) LIKE '%' || ? || '%'`,
+	},
+	{
+		Sql:      `col1 = ? AND col2 = ?`,
+		Dialect:  naivequeryfilters.DialectSQLServer,
+		Expected: `col1 = @p1 AND col2 = @p2`,
+	},
+	{
+		Sql:      `col1 = ? AND col2 = ?`,
+		Dialect:  naivequeryfilters.DialectNamed,
+		Expected: `col1 = :arg1 AND col2 = :arg2`,
+	},
+	{
+		Sql:      `LOWER(col1) LIKE CONCAT('%', LOWER(?), '%')`,
+		Dialect:  naivequeryfilters.DialectPostgres,
+		Expected: `col1 ILIKE '%' || $1 || '%'`,
+	},
+	{
+		// Not Postgres, so the approx fallback shape is left untouched: it stays
+		// portable (and correct) on every non-Postgres dialect as-is.
+		Sql:      `LOWER(col1) LIKE CONCAT('%', LOWER(?), '%')`,
+		Dialect:  naivequeryfilters.DialectSQLite,
+		Expected: `LOWER(col1) LIKE CONCAT('%', LOWER(?), '%')`,
+	},
+}
+
+func TestRebind(t *testing.T) {
+	for _, testCase := range rebindTestData {
+		actual := naivequeryfilters.Rebind(testCase.Sql, testCase.Dialect)
+		assert.Equal(t, testCase.Expected, actual, "rebinding %q for dialect %v", testCase.Sql, testCase.Dialect)
+	}
+}
+
+func TestBuildWithDialect(t *testing.T) {
+	filter, invalidParams, err := naivequeryfilters.BuildWithDialect(whitelist, url.Values{
+		"col1__in": []string{"1", "2"},
+		"col2":     []string{"1"},
+	}, naivequeryfilters.DialectPostgres)
+	assert.NoError(t, err)
+	assert.Nil(t, invalidParams)
+	assert.Equal(t, `col1 IN ($1,$2) AND col2 = $3`, filter.SqlFilters)
+	assert.Equal(t, []any{"1", "2", "1"}, filter.PlaceholderValues)
+}
+
+func TestBuildNamed(t *testing.T) {
+	sql, values, invalidParams, err := naivequeryfilters.BuildNamed(whitelist, url.Values{
+		"col1__in": []string{"1", "2"},
+		"col2":     []string{"1"},
+		"col3":     []string{"val"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `col1 IN (:col1_0,:col1_1) AND col2 = :col2_0`, sql)
+	assert.Equal(t, map[string]any{"col1_0": "1", "col1_1": "2", "col2_0": "1"}, values)
+	assert.Equal(t, url.Values{"col3": []string{"val"}}, invalidParams)
+}
+
+func TestBuildNamedTwoOperatorsOnSameColumn(t *testing.T) {
+	// Same column, two operators: both must survive (AND-joined), with distinct named
+	// placeholders instead of the second silently overwriting the first's "col1_0".
+	sql, values, invalidParams, err := naivequeryfilters.BuildNamed(whitelist, url.Values{
+		"col1__gt": []string{"1"},
+		"col1__lt": []string{"5"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, invalidParams)
+	assert.Equal(t, `col1 > :col1_0 AND col1 < :col1_1_0`, sql)
+	assert.Equal(t, map[string]any{"col1_0": "1", "col1_1_0": "5"}, values)
+}
+
+func TestApproxOperatorFallback(t *testing.T) {
+	filter, _, err := naivequeryfilters.Build(whitelist, url.Values{
+		"col1__approx": []string{"foo"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `LOWER(col1) LIKE CONCAT('%', LOWER(?), '%')`, filter.SqlFilters)
+	assert.Equal(t, []any{"foo"}, filter.PlaceholderValues)
+}
+
+func TestApproxOperatorIlikeAlias(t *testing.T) {
+	filter, _, err := naivequeryfilters.Build(whitelist, url.Values{
+		"col1__ilike": []string{"foo"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `LOWER(col1) LIKE CONCAT('%', LOWER(?), '%')`, filter.SqlFilters)
+}
+
+func TestFilterWithTrigramFromFallback(t *testing.T) {
+	filter, _, err := naivequeryfilters.Build(whitelist, url.Values{
+		"col1__approx": []string{"foo"},
+	})
+	assert.NoError(t, err)
+
+	trigram := filter.WithTrigram(0.3)
+	assert.Equal(t, `col1 % ?`, trigram.SqlFilters)
+	assert.Equal(t, []any{"foo"}, trigram.PlaceholderValues)
+	assert.Equal(t, []string{"SET pg_trgm.similarity_threshold = ?"}, trigram.Preamble)
+	assert.Equal(t, [][]any{{0.3}}, trigram.PreambleValues)
+}
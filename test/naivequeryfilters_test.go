@@ -16,8 +16,8 @@ type caseTestData struct {
 }
 
 var whitelist = naivequeryfilters.AllowedColumns{
-	"col1": func(s string) string { return s },
-	"col2": func(s string) string { return s },
+	"col1": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"col2": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
 }
 
 var testData = []caseTestData{
@@ -101,6 +101,153 @@ var testData = []caseTestData{
 		},
 		ExpectedInvalidParams: make(url.Values),
 	},
+	{
+		ColumnsWhitelist: whitelist,
+		QueryParams: url.Values{
+			"col1__startswith": []string{"foo"},
+			"col2__endswith":   []string{"bar"},
+		},
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `LOWER(col1) LIKE CONCAT(?, '%') AND LOWER(col2) LIKE CONCAT('%', ?)`,
+			PlaceholderValues: []any{"foo", "bar"},
+		},
+		ExpectedInvalidParams: nil,
+	},
+	{
+		ColumnsWhitelist: whitelist,
+		QueryParams: url.Values{
+			"col1__ne": []string{"1"},
+		},
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `col1 <> ?`,
+			PlaceholderValues: []any{"1"},
+		},
+		ExpectedInvalidParams: nil,
+	},
+	{
+		ColumnsWhitelist: whitelist,
+		QueryParams: url.Values{
+			"col1__gt":  []string{"1"},
+			"col2__lte": []string{"10"},
+		},
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `col1 > ? AND col2 <= ?`,
+			PlaceholderValues: []any{"1", "10"},
+		},
+		ExpectedInvalidParams: nil,
+	},
+	{
+		ColumnsWhitelist: whitelist,
+		QueryParams: url.Values{
+			"col1__between": []string{"1", "10"},
+		},
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `col1 BETWEEN ? AND ?`,
+			PlaceholderValues: []any{"1", "10"},
+		},
+		ExpectedInvalidParams: nil,
+	},
+	{
+		ColumnsWhitelist: whitelist,
+		QueryParams: url.Values{
+			"col1__isnull": []string{"true"},
+			"col2__isnull": []string{"false"},
+		},
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `col1 IS NULL AND col2 IS NOT NULL`,
+			PlaceholderValues: []any{},
+		},
+		ExpectedInvalidParams: nil,
+	},
+}
+
+var typedWhitelist = naivequeryfilters.AllowedColumns{
+	"age": naivequeryfilters.ColumnConfig{
+		Rename: func(s string) string { return s },
+		Type:   naivequeryfilters.ColumnTypeInt,
+	},
+}
+
+func TestBuildQueryTypedColumnCoercion(t *testing.T) {
+	filter, _, err := naivequeryfilters.Build(typedWhitelist, url.Values{
+		"age__gt": []string{"18"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `age > ?`, filter.SqlFilters)
+	assert.Equal(t, []any{int64(18)}, filter.PlaceholderValues)
+}
+
+func TestBuildQueryTypedColumnCoercionError(t *testing.T) {
+	_, _, err := naivequeryfilters.Build(typedWhitelist, url.Values{
+		"age__gt": []string{"not-a-number"},
+	})
+	assert.IsType(t, naivequeryfilters.ErrInvalidValue{}, err)
+}
+
+func TestBuildQueryTwoOperatorsOnSameColumn(t *testing.T) {
+	// Two different operators on the same column must both survive and AND-join,
+	// not have the second silently overwrite the first.
+	filter, invalidParams, err := naivequeryfilters.Build(typedWhitelist, url.Values{
+		"age__gt": []string{"18"},
+		"age__lt": []string{"65"},
+	})
+	assert.NoError(t, err)
+	assert.Nil(t, invalidParams)
+	assert.Equal(t, `age > ? AND age < ?`, filter.SqlFilters)
+	assert.Equal(t, []any{int64(18), int64(65)}, filter.PlaceholderValues)
+}
+
+var groupWhitelist = naivequeryfilters.AllowedColumns{
+	"col1": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"col2": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"col3": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"col4": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+}
+
+func TestBuildQueryOrGroups(t *testing.T) {
+	filter, _, err := naivequeryfilters.Build(groupWhitelist, url.Values{
+		"col1__in.g1": []string{"1", "5"},
+		"col2.g1":     []string{"2"},
+		"col3.g2":     []string{"3"},
+		"col4":        []string{"4"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `(col1 IN (?,?) OR col2 = ?) AND col3 = ? AND col4 = ?`, filter.SqlFilters)
+	assert.Equal(t, []any{"1", "5", "2", "3", "4"}, filter.PlaceholderValues)
+}
+
+func TestBuildQueryOrGroupsInterleaved(t *testing.T) {
+	// col1 and col3 share group "g1" despite col2 (ungrouped) and col4 (group "g2")
+	// falling between them alphabetically; they must still combine into one OR group.
+	filter, _, err := naivequeryfilters.Build(groupWhitelist, url.Values{
+		"col1.g1": []string{"1"},
+		"col2":    []string{"2"},
+		"col3.g1": []string{"3"},
+		"col4.g2": []string{"4"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `(col1 = ? OR col3 = ?) AND col2 = ? AND col4 = ?`, filter.SqlFilters)
+	assert.Equal(t, []any{"1", "3", "2", "4"}, filter.PlaceholderValues)
+}
+
+func TestBuildQueryOrGroupsSameColumnDifferentOperators(t *testing.T) {
+	// Same column, same group, two different operators: both filterParams must land in
+	// the group's bucket and OR-join, rather than one overwriting the other.
+	filter, _, err := naivequeryfilters.Build(groupWhitelist, url.Values{
+		"col1__gt.g1": []string{"1"},
+		"col1__lt.g1": []string{"5"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, `(col1 > ? OR col1 < ?)`, filter.SqlFilters)
+	assert.Equal(t, []any{"1", "5"}, filter.PlaceholderValues)
+}
+
+func TestBuildQueryConflictingGroupsError(t *testing.T) {
+	_, _, err := naivequeryfilters.Build(groupWhitelist, url.Values{
+		"col1.g1": []string{"1"},
+		"col1.g2": []string{"2"},
+	})
+	assert.IsType(t, naivequeryfilters.ErrConflictingFilterGroups{}, err)
 }
 
 func TestBuildQuery(t *testing.T) {
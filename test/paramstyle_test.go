@@ -0,0 +1,82 @@
+package naivequeryfilters_test
+
+import (
+	"net/url"
+	"testing"
+
+	naivequeryfilters "github.com/Wayodeni/go-naive-queryfilters"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWithStyleDjango(t *testing.T) {
+	filter, invalidParams, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"col1__in": []string{"1", "2"},
+		"col2":     []string{"1"},
+	}, naivequeryfilters.StyleDjango)
+	assert.NoError(t, err)
+	assert.Nil(t, invalidParams)
+	assert.Equal(t, `col1 IN (?,?) AND col2 = ?`, filter.SqlFilters)
+}
+
+func TestBuildWithStyleBracket(t *testing.T) {
+	filter, invalidParams, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"col1[in]": []string{"1", "2"},
+		"col2[]":   []string{"1"},
+	}, naivequeryfilters.StyleBracket)
+	assert.NoError(t, err)
+	assert.Nil(t, invalidParams)
+	assert.Equal(t, `col1 IN (?,?) AND col2 = ?`, filter.SqlFilters)
+}
+
+func TestBuildWithStyleBracketNestedFilterWrapper(t *testing.T) {
+	filter, _, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"filter[col1][in]": []string{"1", "2"},
+	}, naivequeryfilters.StyleBracket)
+	assert.NoError(t, err)
+	assert.Equal(t, `col1 IN (?,?)`, filter.SqlFilters)
+}
+
+func TestBuildWithStyleBracketLiteralFilterColumn(t *testing.T) {
+	// A real whitelisted column named "filter" addressed via a 2-token bracketed key
+	// must NOT be misread as the "filter[col][op]" nesting convention.
+	literalWhitelist := naivequeryfilters.AllowedColumns{
+		"filter": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	}
+	filter, invalidParams, err := naivequeryfilters.BuildWithStyle(literalWhitelist, url.Values{
+		"filter[in]": []string{"1", "2"},
+	}, naivequeryfilters.StyleBracket)
+	assert.NoError(t, err)
+	assert.Nil(t, invalidParams)
+	assert.Equal(t, `filter IN (?,?)`, filter.SqlFilters)
+}
+
+func TestBuildWithStyleRails(t *testing.T) {
+	filter, _, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"col1[]": []string{"1", "2"},
+		"col2":   []string{"1"},
+	}, naivequeryfilters.StyleRails)
+	assert.NoError(t, err)
+	assert.Equal(t, `col1 IN (?,?) AND col2 = ?`, filter.SqlFilters)
+}
+
+func TestBuildWithStyleRailsRejectsOperatorBrackets(t *testing.T) {
+	_, _, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"col1[in]": []string{"1"},
+	}, naivequeryfilters.StyleRails)
+	assert.IsType(t, naivequeryfilters.ErrWrongQueryParamName{}, err)
+}
+
+func TestBuildWithStyleAmbiguousArrayError(t *testing.T) {
+	_, _, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"col1":   []string{"1"},
+		"col1[]": []string{"2"},
+	}, naivequeryfilters.StyleBracket)
+	assert.IsType(t, naivequeryfilters.ErrAmbiguousArray{}, err)
+}
+
+func TestBuildWithStyleMalformedKeyError(t *testing.T) {
+	_, _, err := naivequeryfilters.BuildWithStyle(whitelist, url.Values{
+		"col1[a][b]": []string{"1"},
+	}, naivequeryfilters.StyleBracket)
+	assert.IsType(t, naivequeryfilters.ErrWrongQueryParamName{}, err)
+}
@@ -0,0 +1,98 @@
+package naivequeryfilters_test
+
+import (
+	"testing"
+
+	naivequeryfilters "github.com/Wayodeni/go-naive-queryfilters"
+	"github.com/stretchr/testify/assert"
+)
+
+type scimTestCase struct {
+	ColumnsWhitelist naivequeryfilters.AllowedColumns
+	Expression       string
+	ExpectedFilter   naivequeryfilters.Filter
+	ExpectError      bool
+}
+
+var scimWhitelist = naivequeryfilters.AllowedColumns{
+	"userName": naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"emails":   naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"active":   naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }},
+	"age":      naivequeryfilters.ColumnConfig{Rename: func(s string) string { return s }, Type: naivequeryfilters.ColumnTypeInt},
+}
+
+var scimTestData = []scimTestCase{
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `userName eq "bob"`,
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `userName = ?`,
+			PlaceholderValues: []any{"bob"},
+		},
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `userName eq "bob" and (emails co "@x" or active pr)`,
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `userName = ? AND (LOWER(emails) LIKE CONCAT('%', ?, '%') OR active IS NOT NULL)`,
+			PlaceholderValues: []any{"bob", "@x"},
+		},
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `not (active pr)`,
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `NOT (active IS NOT NULL)`,
+			PlaceholderValues: nil,
+		},
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `age gt 18 and age le 65`,
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `age > ? AND age <= ?`,
+			PlaceholderValues: []any{int64(18), int64(65)},
+		},
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `userName sw "bo" and userName ew "b"`,
+		ExpectedFilter: naivequeryfilters.Filter{
+			SqlFilters:        `LOWER(userName) LIKE CONCAT(?, '%') AND LOWER(userName) LIKE CONCAT('%', ?)`,
+			PlaceholderValues: []any{"bo", "b"},
+		},
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `unknownAttr eq "bob"`,
+		ExpectError:      true,
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `userName eq "bob" and`,
+		ExpectError:      true,
+	},
+	{
+		ColumnsWhitelist: scimWhitelist,
+		Expression:       `(userName eq "bob"`,
+		ExpectError:      true,
+	},
+}
+
+func TestBuildFromFilterExpression(t *testing.T) {
+	for _, testCase := range scimTestData {
+		filter, err := naivequeryfilters.BuildFromFilterExpression(testCase.ColumnsWhitelist, testCase.Expression)
+		if testCase.ExpectError {
+			assert.Error(t, err, "expression %q should have failed to parse", testCase.Expression)
+			continue
+		}
+		assert.NoError(t, err, "expression %q should have parsed", testCase.Expression)
+		assert.Equal(t, testCase.ExpectedFilter.SqlFilters, filter.SqlFilters, "sql in filter does not equal")
+		assert.Equal(t, testCase.ExpectedFilter.PlaceholderValues, filter.PlaceholderValues, "placeholder values in filter does not equal")
+	}
+}
+
+func TestBuildFromFilterExpressionInvalidFilterExpressionError(t *testing.T) {
+	_, err := naivequeryfilters.BuildFromFilterExpression(scimWhitelist, `userName eq "bob" and`)
+	assert.IsType(t, naivequeryfilters.ErrInvalidFilterExpression{}, err)
+}